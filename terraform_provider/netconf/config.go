@@ -0,0 +1,77 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// cdataEscape wraps text in a CDATA section so set/text/json payloads can
+// contain "&", "<" or ">" without producing malformed load-configuration
+// XML. "]]>" cannot appear inside a CDATA section, so any occurrence is
+// split across adjacent sections.
+func cdataEscape(text string) string {
+	text = strings.ReplaceAll(text, "]]>", "]]]]><![CDATA[>")
+	return "<![CDATA[" + text + "]]>"
+}
+
+// LoadConfig pushes payload into the candidate configuration using the
+// given format ("set", "text", "json" or "xml") and action (e.g. "merge",
+// "replace", "override", "update"), wrapping it in the RPC envelope Junos
+// expects for that format, and returns the structured
+// load-configuration-results reply so callers can inspect per-statement
+// errors.
+func (s *Session) LoadConfig(format, action string, payload io.Reader) (*RPCReply, error) {
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(payload); err != nil {
+		return nil, err
+	}
+
+	var body string
+	switch format {
+	case "set":
+		body = fmt.Sprintf("<configuration-set>%s</configuration-set>", cdataEscape(buf.String()))
+	case "text":
+		body = fmt.Sprintf("<configuration-text>%s</configuration-text>", cdataEscape(buf.String()))
+	case "json":
+		body = fmt.Sprintf("<configuration-json>%s</configuration-json>", cdataEscape(buf.String()))
+	case "xml":
+		// The xml format's payload is itself NETCONF config XML, not text,
+		// so it is embedded as-is rather than CDATA-wrapped.
+		body = fmt.Sprintf("<configuration>%s</configuration>", buf.String())
+	default:
+		return nil, fmt.Errorf("netconf: unsupported load-configuration format %q", format)
+	}
+
+	rpc := fmt.Sprintf(`<load-configuration format="%s" action="%s">%s</load-configuration>`, format, action, body)
+	return s.Exec(RawMethod(rpc))
+}
+
+// Commit commits the candidate configuration.
+func (s *Session) Commit() (*RPCReply, error) {
+	return s.Exec(RawMethod("<commit/>"))
+}
+
+// CommitConfirmed commits the candidate configuration, automatically
+// rolling back if a follow-up Commit is not issued within timeoutSeconds.
+func (s *Session) CommitConfirmed(timeoutSeconds int) (*RPCReply, error) {
+	rpc := fmt.Sprintf("<commit><confirmed/><confirm-timeout>%d</confirm-timeout></commit>", timeoutSeconds)
+	return s.Exec(RawMethod(rpc))
+}
+
+// CommitCheck validates the candidate configuration without committing it.
+func (s *Session) CommitCheck() (*RPCReply, error) {
+	return s.Exec(RawMethod("<commit-configuration><check/></commit-configuration>"))
+}
+
+// Rollback reverts the candidate configuration to rollback state id (0 is
+// the most recent commit).
+func (s *Session) Rollback(id int) (*RPCReply, error) {
+	rpc := fmt.Sprintf("<load-configuration rollback=\"%d\"/>", id)
+	return s.Exec(RawMethod(rpc))
+}
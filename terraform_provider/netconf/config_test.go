@@ -0,0 +1,39 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import "testing"
+
+func TestCdataEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "plain text",
+			in:   "set interfaces ge-0/0/0 unit 0 family inet",
+			want: "<![CDATA[set interfaces ge-0/0/0 unit 0 family inet]]>",
+		},
+		{
+			name: "ampersand and angle brackets",
+			in:   "description \"A & B <tunnel>\"",
+			want: "<![CDATA[description \"A & B <tunnel>\"]]>",
+		},
+		{
+			name: "embedded cdata terminator",
+			in:   "before]]>after",
+			want: "<![CDATA[before]]]]><![CDATA[>after]]>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cdataEscape(tt.in); got != tt.want {
+				t.Errorf("cdataEscape(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import "context"
+
+// ExecContext is like Exec but returns ctx.Err() if ctx is cancelled or its
+// deadline expires before the reply arrives. On cancellation the
+// underlying transport is closed to unblock whatever goroutine is blocked
+// reading from it; the caller should treat the session as unusable after a
+// context error and Close it.
+func (s *Session) ExecContext(ctx context.Context, methods ...RPCMethod) (*RPCReply, error) {
+	type result struct {
+		reply *RPCReply
+		err   error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		reply, err := s.Exec(methods...)
+		done <- result{reply, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.reply, r.err
+	case <-ctx.Done():
+		s.Transport.Close()
+		return nil, ctx.Err()
+	}
+}
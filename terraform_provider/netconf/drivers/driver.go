@@ -0,0 +1,84 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package drivers provides a uniform Driver abstraction over the on-box
+// (Junos local shell) and remote (SSH) NETCONF transports exposed by the
+// netconf package, so callers do not have to hand-assemble RPC XML for
+// routine operations like locking the candidate config or committing.
+package drivers
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/natalielordd/junos-terraform/terraform_provider/netconf"
+)
+
+// Driver is satisfied by anything that can open a NETCONF session and drive
+// the handful of RPCs every config-management caller needs. DriverJunos and
+// DriverSSH are the two transports provided by this package; callers pick
+// the one that matches how they reach the target device.
+type Driver interface {
+	// Dial opens the underlying transport and establishes a NETCONF session.
+	Dial() error
+
+	// DialTimeout is like Dial but fails if the session is not established
+	// within d. DriverSSH bounds only the TCP connection by d.
+	DialTimeout(d time.Duration) error
+
+	// DialContext is like Dial but bounded by ctx, letting callers (e.g. a
+	// terraform resource) bound dial time with their own timeout instead of
+	// hanging the whole apply. DriverJunos aborts as soon as ctx is
+	// cancelled and ends the session if ctx is cancelled later; DriverSSH
+	// uses only ctx's deadline, as its TCP connection timeout, and does not
+	// react to cancellation.
+	DialContext(ctx context.Context) error
+
+	// Close tears down the NETCONF session and its underlying transport.
+	Close() error
+
+	// Lock locks the given datastore (e.g. "candidate" or "running"), or
+	// the driver's default datastore if datastore is "".
+	Lock(datastore string) (*netconf.RPCReply, error)
+
+	// Unlock releases a lock previously taken with Lock.
+	Unlock(datastore string) (*netconf.RPCReply, error)
+
+	// GetConfig retrieves the configuration from the given datastore, or
+	// the driver's default datastore if source is "".
+	GetConfig(source string) (*netconf.RPCReply, error)
+
+	// EditConfig merges configXML into the target datastore, or the
+	// driver's default datastore if target is "".
+	EditConfig(target, configXML string) (*netconf.RPCReply, error)
+
+	// Commit commits the candidate configuration.
+	Commit() (*netconf.RPCReply, error)
+
+	// DiscardChanges discards any uncommitted changes in the candidate
+	// datastore.
+	DiscardChanges() (*netconf.RPCReply, error)
+
+	// SendRaw sends a pre-built RPC payload and returns the raw reply, for
+	// callers that need to fall back to hand-written XML.
+	SendRaw(xml string) (*netconf.RPCReply, error)
+
+	// LoadConfig pushes payload into the candidate configuration using the
+	// given format ("set", "text", "json" or "xml") and action.
+	LoadConfig(format, action string, payload io.Reader) (*netconf.RPCReply, error)
+
+	// CommitConfirmed commits the candidate configuration, automatically
+	// rolling back if a follow-up Commit is not issued within
+	// timeoutSeconds.
+	CommitConfirmed(timeoutSeconds int) (*netconf.RPCReply, error)
+
+	// CommitCheck validates the candidate configuration without committing
+	// it.
+	CommitCheck() (*netconf.RPCReply, error)
+
+	// Rollback reverts the candidate configuration to rollback state id (0
+	// is the most recent commit).
+	Rollback(id int) (*netconf.RPCReply, error)
+}
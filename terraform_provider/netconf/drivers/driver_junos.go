@@ -0,0 +1,108 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package drivers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/natalielordd/junos-terraform/terraform_provider/netconf"
+)
+
+// DriverJunos drives a NETCONF session over the Junos local shell interface
+// (xml-mode netconf need-trailer). It is the right choice when the caller is
+// already running on the Junos device itself, e.g. inside an op-script or a
+// local commit-script.
+type DriverJunos struct {
+	sessionOps
+
+	// Facts caches the result of the most recent GatherFacts call.
+	Facts *netconf.SystemInformation
+
+	transport *netconf.TransportJunos
+}
+
+// NewDriverJunos returns a DriverJunos defaulted to the candidate datastore.
+func NewDriverJunos() *DriverJunos {
+	return &DriverJunos{sessionOps: sessionOps{Datastore: "candidate"}}
+}
+
+// Dial opens the local NETCONF session.
+func (d *DriverJunos) Dial() error {
+	d.transport = &netconf.TransportJunos{}
+	if err := d.transport.Open(); err != nil {
+		return err
+	}
+
+	session, err := netconf.NewSessionFromTransport(d.transport)
+	if err != nil {
+		return err
+	}
+	d.session = session
+	return nil
+}
+
+// DialTimeout opens the local NETCONF session, failing if it is not
+// established within d.
+func (dr *DriverJunos) DialTimeout(d time.Duration) error {
+	// OpenContext watches ctx for the life of the session, so ctx is
+	// cancelled only if d elapses before the dial completes; cancelling it
+	// after a successful dial would kill the session just established.
+	ctx, cancel := context.WithCancel(context.Background())
+	timer := time.AfterFunc(d, cancel)
+
+	err := dr.DialContext(ctx)
+	if !timer.Stop() {
+		return fmt.Errorf("netconf: timed out dialing Junos local shell after %s", d)
+	}
+	if err != nil {
+		cancel()
+		return err
+	}
+	return nil
+}
+
+// DialContext opens the local NETCONF session, aborting as soon as ctx is
+// cancelled. The session stays tied to ctx: cancelling ctx after
+// DialContext has returned kills the xml-mode child and ends the session.
+func (d *DriverJunos) DialContext(ctx context.Context) error {
+	d.transport = &netconf.TransportJunos{}
+	if err := d.transport.OpenContext(ctx); err != nil {
+		return err
+	}
+
+	session, err := netconf.NewSessionFromTransport(d.transport)
+	if err != nil {
+		return err
+	}
+	d.session = session
+	return nil
+}
+
+// GatherFacts issues get-system-information and get-software-information,
+// merges the two replies into a SystemInformation, caches it on Facts, and
+// returns it. Callers that just connected and need model/version/hostname/
+// serial before doing anything else should call this once up front instead
+// of hand-rolling the same two RPCs themselves.
+func (d *DriverJunos) GatherFacts() (*netconf.SystemInformation, error) {
+	sysReply, err := d.session.Exec(netconf.RawMethod("<get-system-information/>"))
+	if err != nil {
+		return nil, err
+	}
+
+	softwareReply, err := d.session.Exec(netconf.RawMethod("<get-software-information/>"))
+	if err != nil {
+		return nil, err
+	}
+
+	facts, err := netconf.ParseFacts(sysReply, softwareReply)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Facts = facts
+	return facts, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package drivers
+
+import (
+	"context"
+	"time"
+
+	"github.com/natalielordd/junos-terraform/terraform_provider/netconf"
+	"golang.org/x/crypto/ssh"
+)
+
+// DriverSSH drives a NETCONF session over SSH (RFC 6242 subsystem
+// "netconf"). It is the right choice when the caller is off-box, e.g. a
+// terraform provider applying configuration against a remote device.
+type DriverSSH struct {
+	sessionOps
+
+	Target       string
+	ClientConfig *ssh.ClientConfig
+}
+
+// NewDriverSSH returns a DriverSSH defaulted to the candidate datastore,
+// dialing target (host:port) with config.
+func NewDriverSSH(target string, config *ssh.ClientConfig) *DriverSSH {
+	return &DriverSSH{
+		sessionOps:   sessionOps{Datastore: "candidate"},
+		Target:       target,
+		ClientConfig: config,
+	}
+}
+
+// Dial opens the SSH NETCONF session.
+func (d *DriverSSH) Dial() error {
+	session, err := netconf.DialSSH(d.Target, d.ClientConfig)
+	if err != nil {
+		return err
+	}
+	d.session = session
+	return nil
+}
+
+// DialTimeout opens the SSH NETCONF session, failing if the TCP connection
+// is not established within timeout. The SSH handshake and the <hello>
+// exchange are not bounded by timeout.
+func (d *DriverSSH) DialTimeout(timeout time.Duration) error {
+	session, err := netconf.DialSSHTimeout(d.Target, d.ClientConfig, timeout)
+	if err != nil {
+		return err
+	}
+	d.session = session
+	return nil
+}
+
+// DialContext opens the SSH NETCONF session, using ctx's deadline (if any)
+// as the TCP connection timeout. Cancelling ctx does not abort the dial, and
+// the SSH handshake and <hello> exchange are not bounded. The bound is
+// enforced by the underlying ssh.ClientConfig.Timeout rather than a
+// background goroutine racing time.After, so a timed-out dial can't keep
+// running after DialContext has already returned and concurrently write
+// d.session.
+func (d *DriverSSH) DialContext(ctx context.Context) error {
+	var timeout time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	return d.DialTimeout(timeout)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package drivers
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/natalielordd/junos-terraform/terraform_provider/netconf"
+)
+
+// sessionOps implements the RPC-building methods shared by DriverJunos and
+// DriverSSH once a Session has been established, so the two transports
+// don't each carry their own copy of the same RPC bodies. DriverJunos and
+// DriverSSH embed it and supply their own Dial/DialTimeout/DialContext.
+type sessionOps struct {
+	// Datastore is the datastore Lock/Unlock/GetConfig/EditConfig operate
+	// against when called with an empty string. It defaults to
+	// "candidate".
+	Datastore string
+
+	session *netconf.Session
+}
+
+func (s *sessionOps) datastoreOrDefault(datastore string) string {
+	if datastore == "" {
+		return s.Datastore
+	}
+	return datastore
+}
+
+// Close closes the NETCONF session and its underlying transport.
+func (s *sessionOps) Close() error {
+	if s.session != nil {
+		return s.session.Close()
+	}
+	return nil
+}
+
+// Lock locks the given datastore, or Datastore if datastore is "".
+func (s *sessionOps) Lock(datastore string) (*netconf.RPCReply, error) {
+	datastore = s.datastoreOrDefault(datastore)
+	return s.session.Exec(netconf.RawMethod(fmt.Sprintf("<lock><target><%s/></target></lock>", datastore)))
+}
+
+// Unlock releases a lock previously taken with Lock.
+func (s *sessionOps) Unlock(datastore string) (*netconf.RPCReply, error) {
+	datastore = s.datastoreOrDefault(datastore)
+	return s.session.Exec(netconf.RawMethod(fmt.Sprintf("<unlock><target><%s/></target></unlock>", datastore)))
+}
+
+// GetConfig retrieves the configuration from source, or Datastore if source
+// is "".
+func (s *sessionOps) GetConfig(source string) (*netconf.RPCReply, error) {
+	source = s.datastoreOrDefault(source)
+	return s.session.Exec(netconf.RawMethod(fmt.Sprintf("<get-configuration><source><%s/></source></get-configuration>", source)))
+}
+
+// EditConfig merges configXML into target, or Datastore if target is "".
+func (s *sessionOps) EditConfig(target, configXML string) (*netconf.RPCReply, error) {
+	target = s.datastoreOrDefault(target)
+	rpc := fmt.Sprintf("<edit-config><target><%s/></target><config>%s</config></edit-config>", target, configXML)
+	return s.session.Exec(netconf.RawMethod(rpc))
+}
+
+// Commit commits the candidate configuration.
+func (s *sessionOps) Commit() (*netconf.RPCReply, error) {
+	return s.session.Exec(netconf.RawMethod("<commit/>"))
+}
+
+// DiscardChanges discards any uncommitted changes in the candidate
+// datastore.
+func (s *sessionOps) DiscardChanges() (*netconf.RPCReply, error) {
+	return s.session.Exec(netconf.RawMethod("<discard-changes/>"))
+}
+
+// SendRaw sends a pre-built RPC payload and returns the raw reply.
+func (s *sessionOps) SendRaw(xml string) (*netconf.RPCReply, error) {
+	return s.session.Exec(netconf.RawMethod(xml))
+}
+
+// LoadConfig pushes payload into the candidate configuration using the
+// given format ("set", "text", "json" or "xml") and action.
+func (s *sessionOps) LoadConfig(format, action string, payload io.Reader) (*netconf.RPCReply, error) {
+	return s.session.LoadConfig(format, action, payload)
+}
+
+// CommitConfirmed commits the candidate configuration, automatically
+// rolling back if a follow-up Commit is not issued within timeoutSeconds.
+func (s *sessionOps) CommitConfirmed(timeoutSeconds int) (*netconf.RPCReply, error) {
+	return s.session.CommitConfirmed(timeoutSeconds)
+}
+
+// CommitCheck validates the candidate configuration without committing it.
+func (s *sessionOps) CommitCheck() (*netconf.RPCReply, error) {
+	return s.session.CommitCheck()
+}
+
+// Rollback reverts the candidate configuration to rollback state id (0 is
+// the most recent commit).
+func (s *sessionOps) Rollback(id int) (*netconf.RPCReply, error) {
+	return s.session.Rollback(id)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import "encoding/xml"
+
+// SystemInformation holds the identifying facts gathered from a Junos
+// device right after connecting: model, software version, hostname,
+// serial number, and (on a chassis cluster) which node this session
+// landed on.
+type SystemInformation struct {
+	HardwareModel string `xml:"hardware-model"`
+	OSName        string `xml:"os-name"`
+	OSVersion     string `xml:"os-version"`
+	SerialNumber  string `xml:"serial-number"`
+	HostName      string `xml:"host-name"`
+	ClusterNode   string `xml:"cluster-node"`
+}
+
+type getSystemInformationReply struct {
+	XMLName           xml.Name          `xml:"rpc-reply"`
+	SystemInformation SystemInformation `xml:"system-information"`
+}
+
+type getSoftwareInformationReply struct {
+	XMLName             xml.Name `xml:"rpc-reply"`
+	SoftwareInformation struct {
+		HostName           string `xml:"host-name"`
+		ProductModel       string `xml:"product-model"`
+		ClusterNode        string `xml:"cluster-node"`
+		PackageInformation []struct {
+			Comment string `xml:"comment"`
+		} `xml:"package-information"`
+	} `xml:"software-information"`
+}
+
+// ParseFacts merges a get-system-information and a get-software-information
+// reply into a single SystemInformation. Either reply may leave fields
+// zero-valued; the other fills them in where it can.
+func ParseFacts(sysReply, softwareReply *RPCReply) (*SystemInformation, error) {
+	var sys getSystemInformationReply
+	if err := xml.Unmarshal([]byte(sysReply.RawReply), &sys); err != nil {
+		return nil, err
+	}
+
+	var software getSoftwareInformationReply
+	if err := xml.Unmarshal([]byte(softwareReply.RawReply), &software); err != nil {
+		return nil, err
+	}
+
+	facts := sys.SystemInformation
+	if facts.HostName == "" {
+		facts.HostName = software.SoftwareInformation.HostName
+	}
+	if facts.HardwareModel == "" {
+		facts.HardwareModel = software.SoftwareInformation.ProductModel
+	}
+	if facts.ClusterNode == "" {
+		facts.ClusterNode = software.SoftwareInformation.ClusterNode
+	}
+	if facts.OSVersion == "" {
+		for _, pkg := range software.SoftwareInformation.PackageInformation {
+			if pkg.Comment != "" {
+				facts.OSVersion = pkg.Comment
+				break
+			}
+		}
+	}
+
+	return &facts, nil
+}
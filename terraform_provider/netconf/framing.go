@@ -0,0 +1,135 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+const (
+	baseVersion1_0 = "1.0"
+	baseVersion1_1 = "1.1"
+
+	// maxChunkSize is the RFC 6242 chunk-size upper bound.
+	maxChunkSize = 4294967295
+)
+
+// Version reports the base version currently in effect. It defaults to 1.0
+// until SetVersion is called, which happens once the peer's <hello>
+// capabilities have been parsed and both sides advertise base:1.1. Callers
+// talking to a peer that mis-advertises 1.1 support can force 1.0 by
+// calling SetVersion("1.0") themselves after Dial.
+func (t *TransportBasicIO) Version() string {
+	if t.version == "" {
+		return baseVersion1_0
+	}
+	return t.version
+}
+
+// BaseVersion reports the NETCONF base version ("1.0" or "1.1") negotiated
+// for this session.
+func (s *Session) BaseVersion() string {
+	return s.Transport.Version()
+}
+
+// sendChunked writes msg as a single RFC 6242 chunk followed by the
+// end-of-chunks marker: "\n#<len>\n<chunk>\n##\n".
+func (t *TransportBasicIO) sendChunked(msg []byte) error {
+	if len(msg) > maxChunkSize {
+		return fmt.Errorf("netconf: message of %d bytes exceeds max chunk-size of %d", len(msg), maxChunkSize)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "\n#%d\n", len(msg))
+	buf.Write(msg)
+	buf.WriteString("\n##\n")
+
+	_, err := t.Write(buf.Bytes())
+	return err
+}
+
+// receiveChunked reads one or more RFC 6242 chunks up to the end-of-chunks
+// marker and returns their concatenated payload.
+func (t *TransportBasicIO) receiveChunked() ([]byte, error) {
+	reader := t.bufReader()
+
+	var out bytes.Buffer
+	for {
+		chunk, end, err := readChunk(reader)
+		if err != nil {
+			return nil, err
+		}
+		if end {
+			break
+		}
+		out.Write(chunk)
+	}
+	return out.Bytes(), nil
+}
+
+// readChunk reads one "\n#<len>\n<chunk>" chunk, or the "\n##\n"
+// end-of-chunks marker, rejecting any malformed chunk header.
+func readChunk(reader *bufio.Reader) (chunk []byte, end bool, err error) {
+	if b, err := reader.ReadByte(); err != nil {
+		return nil, false, err
+	} else if b != '\n' {
+		return nil, false, fmt.Errorf("netconf: malformed chunk framing, expected leading '\\n', got %q", b)
+	}
+
+	if b, err := reader.ReadByte(); err != nil {
+		return nil, false, err
+	} else if b != '#' {
+		return nil, false, fmt.Errorf("netconf: malformed chunk framing, expected '#', got %q", b)
+	}
+
+	b, err := reader.ReadByte()
+	if err != nil {
+		return nil, false, err
+	}
+	if b == '#' {
+		if nl, err := reader.ReadByte(); err != nil {
+			return nil, false, err
+		} else if nl != '\n' {
+			return nil, false, fmt.Errorf("netconf: malformed end-of-chunks marker, expected '\\n', got %q", nl)
+		}
+		return nil, true, nil
+	}
+
+	var digits []byte
+	for {
+		if b < '0' || b > '9' {
+			return nil, false, fmt.Errorf("netconf: malformed chunk-size header, non-digit byte %q", b)
+		}
+		digits = append(digits, b)
+		if len(digits) > 10 {
+			return nil, false, fmt.Errorf("netconf: chunk-size header too long")
+		}
+
+		b, err = reader.ReadByte()
+		if err != nil {
+			return nil, false, err
+		}
+		if b == '\n' {
+			break
+		}
+	}
+
+	var size uint64
+	for _, d := range digits {
+		size = size*10 + uint64(d-'0')
+	}
+	if size == 0 || size > maxChunkSize {
+		return nil, false, fmt.Errorf("netconf: chunk-size %d out of range (1-%d)", size, maxChunkSize)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, false, err
+	}
+	return data, false, nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestReadChunk(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		wantChunk string
+		wantEnd   bool
+		wantErr   bool
+	}{
+		{
+			name:      "single chunk",
+			in:        "\n#11\n<rpc-reply>",
+			wantChunk: "<rpc-reply>",
+		},
+		{
+			name:    "end-of-chunks marker",
+			in:      "\n##\n",
+			wantEnd: true,
+		},
+		{
+			name:    "missing leading newline",
+			in:      "#11\n<rpc-reply>",
+			wantErr: true,
+		},
+		{
+			name:    "missing hash",
+			in:      "\n11\n<rpc-reply>",
+			wantErr: true,
+		},
+		{
+			name:    "non-digit chunk-size byte",
+			in:      "\n#1x\n<rpc-reply>",
+			wantErr: true,
+		},
+		{
+			name:    "chunk-size header too long",
+			in:      "\n#12345678901\n",
+			wantErr: true,
+		},
+		{
+			name:    "zero chunk-size",
+			in:      "\n#0\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed end-of-chunks marker",
+			in:      "\n##x",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := bufio.NewReader(strings.NewReader(tt.in))
+			chunk, end, err := readChunk(reader)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("readChunk(%q) = nil error, want an error", tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readChunk(%q): %v", tt.in, err)
+			}
+			if end != tt.wantEnd {
+				t.Errorf("readChunk(%q) end = %v, want %v", tt.in, end, tt.wantEnd)
+			}
+			if string(chunk) != tt.wantChunk {
+				t.Errorf("readChunk(%q) chunk = %q, want %q", tt.in, chunk, tt.wantChunk)
+			}
+		})
+	}
+}
+
+func TestReceiveChunkedConcatenatesMultipleChunks(t *testing.T) {
+	transport := &TransportBasicIO{
+		ReadWriteCloser: NewReadWriteCloser(strings.NewReader("\n#5\n<rpc>\n#10\n foo</rpc>\n##\n"), nopWriteCloser{}),
+	}
+
+	got, err := transport.receiveChunked()
+	if err != nil {
+		t.Fatalf("receiveChunked: %v", err)
+	}
+	if want := "<rpc> foo</rpc>"; string(got) != want {
+		t.Errorf("receiveChunked = %q, want %q", got, want)
+	}
+}
+
+// nopWriteCloser discards writes, for tests that only exercise Receive.
+type nopWriteCloser struct{}
+
+func (nopWriteCloser) Write(p []byte) (int, error) { return len(p), nil }
+func (nopWriteCloser) Close() error                { return nil }
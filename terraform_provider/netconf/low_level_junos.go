@@ -5,6 +5,7 @@
 package netconf
 
 import (
+	"context"
 	"os/exec"
 )
 
@@ -43,6 +44,24 @@ func (t *TransportJunos) Open() error {
 	return t.cmd.Start()
 }
 
+// OpenContext is like Open, but kills the xml-mode child process as soon as
+// ctx is cancelled, unblocking any goroutine reading from the session so a
+// hung device or wedged child doesn't hang the caller forever.
+func (t *TransportJunos) OpenContext(ctx context.Context) error {
+	if err := t.Open(); err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		if t.cmd != nil && t.cmd.Process != nil {
+			t.cmd.Process.Kill()
+		}
+	}()
+
+	return nil
+}
+
 // Dial creates a new NETCONF session via Junos local shell
 // NETCONF interface (xml-mode netconf need-trailer).
 func lowlevelDial() (*Session, error) {
@@ -52,7 +71,7 @@ func lowlevelDial() (*Session, error) {
 		return nil, err
 	}
 
-	session, err := NewSession(&t)
+	session, err := NewSessionFromTransport(&t)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,97 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Notification is a single RFC 5277 <notification> pushed by the device
+// after a create-subscription RPC, with its payload left as raw XML so
+// callers can unmarshal it into whatever event type the stream produces
+// (route changes, syslog, JTI, etc).
+type Notification struct {
+	XMLName   xml.Name `xml:"notification"`
+	EventTime string   `xml:"eventTime"`
+	Raw       string   `xml:",innerxml"`
+}
+
+// Subscription is a live RFC 5277 event stream started by ExecSubscribe.
+type Subscription struct {
+	// Notifications delivers each notification as it is received. It is
+	// closed once the subscription is closed or the underlying transport
+	// fails.
+	Notifications <-chan Notification
+
+	transport Transport
+	cancel    chan struct{}
+	done      chan struct{}
+}
+
+// Close cancels the subscription and closes the underlying transport to
+// unblock the background reader's in-flight Receive, then waits for it to
+// exit. Since ExecSubscribe leaves the session notification-only, closing
+// the transport here is the same trade-off ExecContext makes on
+// cancellation: the session is unusable afterward.
+func (sub *Subscription) Close() error {
+	close(sub.cancel)
+	sub.transport.Close()
+	<-sub.done
+	return nil
+}
+
+// ExecSubscribe issues a create-subscription RPC for stream (optionally
+// narrowed by an RFC 5277 event filter) and starts a background reader that
+// frames subsequent <notification> messages off the session's transport
+// onto the returned Subscription. Call Subscription.Close to stop it.
+//
+// Once the create-subscription RPC succeeds, the peer stops sending
+// <rpc-reply> messages and only ever sends <notification> messages, so the
+// session becomes notification-only: any later call to Exec returns an
+// error instead of hanging waiting for a reply that will never arrive.
+func (s *Session) ExecSubscribe(stream string, filter string) (*Subscription, error) {
+	rpc := fmt.Sprintf(
+		`<create-subscription xmlns="urn:ietf:params:xml:ns:netconf:notification:1.0"><stream>%s</stream>%s</create-subscription>`,
+		stream, filter,
+	)
+	if _, err := s.Exec(RawMethod(rpc)); err != nil {
+		return nil, err
+	}
+	s.subscribed = true
+
+	notifications := make(chan Notification)
+	sub := &Subscription{
+		Notifications: notifications,
+		transport:     s.Transport,
+		cancel:        make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	go func() {
+		defer close(sub.done)
+		defer close(notifications)
+
+		for {
+			raw, err := s.Transport.Receive()
+			if err != nil {
+				return
+			}
+
+			var n Notification
+			if err := xml.Unmarshal(raw, &n); err != nil {
+				continue
+			}
+
+			select {
+			case notifications <- n:
+			case <-sub.cancel:
+				return
+			}
+		}
+	}()
+
+	return sub, nil
+}
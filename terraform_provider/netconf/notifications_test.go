@@ -0,0 +1,136 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+// blockingTransport is a Transport whose Receive blocks until Close is
+// called, like a real socket/pipe with no message currently in flight.
+type blockingTransport struct {
+	queue   [][]byte
+	closed  chan struct{}
+	version string
+}
+
+func newBlockingTransport(queue [][]byte) *blockingTransport {
+	return &blockingTransport{queue: queue, closed: make(chan struct{})}
+}
+
+func (t *blockingTransport) Send([]byte) error { return nil }
+
+func (t *blockingTransport) Receive() ([]byte, error) {
+	if len(t.queue) > 0 {
+		msg := t.queue[0]
+		t.queue = t.queue[1:]
+		return msg, nil
+	}
+	<-t.closed
+	return nil, io.ErrClosedPipe
+}
+
+func (t *blockingTransport) Close() error {
+	select {
+	case <-t.closed:
+	default:
+		close(t.closed)
+	}
+	return nil
+}
+
+func (t *blockingTransport) SetVersion(version string) { t.version = version }
+func (t *blockingTransport) Version() string {
+	if t.version == "" {
+		return baseVersion1_0
+	}
+	return t.version
+}
+
+// queueTransport is a Transport whose Receive plays back a fixed queue of
+// messages, for tests that need to script a conversation.
+type queueTransport struct {
+	queue   [][]byte
+	version string
+}
+
+func (t *queueTransport) Send([]byte) error { return nil }
+
+func (t *queueTransport) Receive() ([]byte, error) {
+	if len(t.queue) == 0 {
+		return nil, io.EOF
+	}
+	msg := t.queue[0]
+	t.queue = t.queue[1:]
+	return msg, nil
+}
+
+func (t *queueTransport) Close() error              { return nil }
+func (t *queueTransport) SetVersion(version string) { t.version = version }
+func (t *queueTransport) Version() string {
+	if t.version == "" {
+		return baseVersion1_0
+	}
+	return t.version
+}
+
+func TestExecAfterExecSubscribeFails(t *testing.T) {
+	transport := &queueTransport{
+		queue: [][]byte{
+			helloWithCapabilities("urn:ietf:params:netconf:base:1.0"),
+			[]byte(`<rpc-reply message-id="1"/>`),
+		},
+	}
+
+	session, err := NewSession(transport)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+
+	sub, err := session.ExecSubscribe("NETCONF", "")
+	if err != nil {
+		t.Fatalf("ExecSubscribe: %v", err)
+	}
+	defer sub.Close()
+
+	if _, err := session.Exec(RawMethod("<get/>")); err == nil {
+		t.Fatal("Exec after ExecSubscribe: got nil error, want an error")
+	}
+}
+
+func TestSubscriptionCloseUnblocksPendingReceive(t *testing.T) {
+	transport := newBlockingTransport([][]byte{
+		helloWithCapabilities("urn:ietf:params:netconf:base:1.0"),
+		[]byte(`<rpc-reply message-id="1"/>`),
+	})
+
+	session, err := NewSession(transport)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+
+	sub, err := session.ExecSubscribe("NETCONF", "")
+	if err != nil {
+		t.Fatalf("ExecSubscribe: %v", err)
+	}
+
+	// At this point the background reader is blocked in Receive with no
+	// notification in flight, mirroring a real transport with nothing to
+	// read. Close must not hang waiting for a Receive that will never
+	// return on its own.
+	done := make(chan error, 1)
+	go func() { done <- sub.Close() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return within 1s; pending Receive was not unblocked")
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// RPCMethod is implemented by anything that can render itself as the body
+// of an <rpc> element.
+type RPCMethod interface {
+	MarshalMethod() string
+}
+
+// RawMethod is an RPCMethod that renders as the literal XML it was given,
+// for callers assembling their own request bodies.
+type RawMethod string
+
+// MarshalMethod returns m unchanged.
+func (m RawMethod) MarshalMethod() string {
+	return string(m)
+}
+
+// rpcErrorReply is the structure of an individual <rpc-error> inside a
+// <rpc-reply>.
+type rpcErrorReply struct {
+	Severity string `xml:"error-severity"`
+	Message  string `xml:"error-message"`
+}
+
+// RPCReply is the parsed result of an RPC round trip: the raw XML the peer
+// sent back, plus any <rpc-error> elements found in it.
+type RPCReply struct {
+	XMLName xml.Name `xml:"rpc-reply"`
+
+	// RawReply is the complete, unparsed <rpc-reply> XML.
+	RawReply string `xml:"-"`
+
+	Errors []rpcErrorReply `xml:"rpc-error"`
+}
+
+// Ok reports whether the reply carried no rpc-error elements.
+func (r *RPCReply) Ok() bool {
+	return len(r.Errors) == 0
+}
+
+// newRPCMessage wraps the marshaled body of methods in an <rpc> envelope
+// carrying messageID.
+func newRPCMessage(messageID string, methods []RPCMethod) []byte {
+	var body string
+	for _, m := range methods {
+		body += m.MarshalMethod()
+	}
+
+	return []byte(fmt.Sprintf(
+		`<rpc xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" message-id="%s">%s</rpc>`,
+		messageID, body,
+	))
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// clientCapabilities are the base capabilities this package advertises in
+// its own <hello>.
+var clientCapabilities = []string{
+	"urn:ietf:params:netconf:base:1.0",
+	"urn:ietf:params:netconf:base:1.1",
+}
+
+// capabilityBase1_1 is the capability URI both peers must advertise in
+// their <hello> for the session to switch to RFC 6242 chunked framing.
+const capabilityBase1_1 = "urn:ietf:params:netconf:base:1.1"
+
+// helloMessage is the <hello> exchanged by both peers when a session is
+// established.
+type helloMessage struct {
+	XMLName      xml.Name `xml:"urn:ietf:params:xml:ns:netconf:base:1.0 hello"`
+	Capabilities []string `xml:"capabilities>capability"`
+	SessionID    int      `xml:"session-id,omitempty"`
+}
+
+// Session represents an established NETCONF session over a Transport.
+type Session struct {
+	Transport    Transport
+	SessionID    int
+	Capabilities []string
+
+	messageID uint64
+
+	// subscribed is set once ExecSubscribe succeeds. From that point the
+	// peer only ever sends <notification> messages, so Exec can no longer
+	// be used on this session.
+	subscribed bool
+}
+
+// NewSession performs the <hello> exchange over t and returns the
+// resulting Session.
+func NewSession(t Transport) (*Session, error) {
+	if err := t.Send(buildHello(clientCapabilities)); err != nil {
+		return nil, err
+	}
+
+	raw, err := t.Receive()
+	if err != nil {
+		return nil, err
+	}
+
+	var peerHello helloMessage
+	if err := xml.Unmarshal(raw, &peerHello); err != nil {
+		return nil, fmt.Errorf("netconf: malformed <hello> from peer: %v", err)
+	}
+
+	if hasCapability(clientCapabilities, capabilityBase1_1) && hasCapability(peerHello.Capabilities, capabilityBase1_1) {
+		t.SetVersion(baseVersion1_1)
+	}
+
+	return &Session{
+		Transport:    t,
+		SessionID:    peerHello.SessionID,
+		Capabilities: peerHello.Capabilities,
+	}, nil
+}
+
+func hasCapability(capabilities []string, capability string) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+func buildHello(capabilities []string) []byte {
+	var b strings.Builder
+	b.WriteString(`<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>`)
+	for _, c := range capabilities {
+		b.WriteString("<capability>" + c + "</capability>")
+	}
+	b.WriteString("</capabilities></hello>")
+	return []byte(b.String())
+}
+
+// Exec sends methods as a single <rpc> request and returns the parsed
+// reply. It returns an error if the session has been switched to
+// notification-only mode by ExecSubscribe.
+func (s *Session) Exec(methods ...RPCMethod) (*RPCReply, error) {
+	if s.subscribed {
+		return nil, fmt.Errorf("netconf: Exec called after ExecSubscribe; session only delivers notifications")
+	}
+
+	messageID := strconv.FormatUint(atomic.AddUint64(&s.messageID, 1), 10)
+
+	if err := s.Transport.Send(newRPCMessage(messageID, methods)); err != nil {
+		return nil, err
+	}
+
+	raw, err := s.Transport.Receive()
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &RPCReply{RawReply: string(raw)}
+	if err := xml.Unmarshal(raw, reply); err != nil {
+		return nil, fmt.Errorf("netconf: malformed <rpc-reply>: %v", err)
+	}
+
+	return reply, nil
+}
+
+// Close closes the underlying transport.
+func (s *Session) Close() error {
+	return s.Transport.Close()
+}
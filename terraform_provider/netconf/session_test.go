@@ -0,0 +1,69 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import "testing"
+
+// fakeTransport is a minimal Transport that hands back a canned <hello> and
+// records the version NewSession negotiates onto it.
+type fakeTransport struct {
+	peerHello []byte
+	version   string
+}
+
+func (t *fakeTransport) Send([]byte) error         { return nil }
+func (t *fakeTransport) Receive() ([]byte, error)  { return t.peerHello, nil }
+func (t *fakeTransport) Close() error              { return nil }
+func (t *fakeTransport) SetVersion(version string) { t.version = version }
+func (t *fakeTransport) Version() string {
+	if t.version == "" {
+		return baseVersion1_0
+	}
+	return t.version
+}
+
+func helloWithCapabilities(capabilities ...string) []byte {
+	hello := `<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>`
+	for _, c := range capabilities {
+		hello += "<capability>" + c + "</capability>"
+	}
+	hello += `</capabilities><session-id>1</session-id></hello>`
+	return []byte(hello)
+}
+
+func TestNewSessionNegotiatesVersion(t *testing.T) {
+	tests := []struct {
+		name           string
+		peerCapability string
+		wantVersion    string
+	}{
+		{
+			name:           "peer supports base:1.1",
+			peerCapability: "urn:ietf:params:netconf:base:1.1",
+			wantVersion:    baseVersion1_1,
+		},
+		{
+			name:           "peer only supports base:1.0",
+			peerCapability: "urn:ietf:params:netconf:base:1.0",
+			wantVersion:    baseVersion1_0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeTransport{
+				peerHello: helloWithCapabilities("urn:ietf:params:netconf:base:1.0", tt.peerCapability),
+			}
+
+			if _, err := NewSession(transport); err != nil {
+				t.Fatalf("NewSession: %v", err)
+			}
+
+			if got := transport.Version(); got != tt.wantVersion {
+				t.Errorf("negotiated version = %q, want %q", got, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// msgSeparator1_0 is the NETCONF 1.0 end-of-message marker.
+const msgSeparator1_0 = "]]>]]>"
+
+// Transport is satisfied by anything that can carry a NETCONF session:
+// the Junos local shell pipe, an SSH subsystem, or a caller-supplied
+// transport such as TLS, telnet, or a test harness. Session only talks to
+// this interface, so new transports can be plugged in without touching
+// session handling or RPC building.
+type Transport interface {
+	// Send writes a single NETCONF message, framed as required by the
+	// negotiated base version.
+	Send([]byte) error
+
+	// Receive reads and returns a single framed NETCONF message.
+	Receive() ([]byte, error)
+
+	// Close releases the underlying connection.
+	Close() error
+
+	// SetVersion switches the framing used by Send/Receive once the
+	// NETCONF base version has been negotiated via <hello>.
+	SetVersion(string)
+
+	// Version reports the base version currently in effect ("1.0" or
+	// "1.1").
+	Version() string
+}
+
+// TransportBasicIO implements Transport on top of any io.ReadWriteCloser,
+// handling NETCONF 1.0 `]]>]]>` framing. It is embedded by TransportJunos
+// and TransportSSH, and can be embedded by caller-supplied transports too.
+type TransportBasicIO struct {
+	io.ReadWriteCloser
+	reader  *bufio.Reader
+	version string
+}
+
+// NewReadWriteCloser wraps r and w into a single io.ReadWriteCloser suitable
+// for embedding in a TransportBasicIO. r need not implement io.Closer (e.g.
+// an ssh.Session's StdoutPipe does not); only w is closed.
+func NewReadWriteCloser(r io.Reader, w io.WriteCloser) io.ReadWriteCloser {
+	return &rwc{r: r, w: w}
+}
+
+// rwc glues together a separate Reader and WriteCloser, such as the
+// stdout/stdin pipes of a local subprocess or SSH session.
+type rwc struct {
+	r io.Reader
+	w io.WriteCloser
+}
+
+func (c *rwc) Read(p []byte) (int, error)  { return c.r.Read(p) }
+func (c *rwc) Write(p []byte) (int, error) { return c.w.Write(p) }
+func (c *rwc) Close() error                { return c.w.Close() }
+
+// NewSessionFromTransport establishes a NETCONF session over an arbitrary
+// Transport, performing the <hello> exchange. Use this to plug in a
+// caller-supplied transport (TLS, telnet, a test harness) instead of the
+// built-in Junos or SSH ones.
+func NewSessionFromTransport(t Transport) (*Session, error) {
+	return NewSession(t)
+}
+
+func (t *TransportBasicIO) bufReader() *bufio.Reader {
+	if t.reader == nil {
+		t.reader = bufio.NewReader(t.ReadWriteCloser)
+	}
+	return t.reader
+}
+
+// SetVersion switches the framing used by Send/Receive.
+func (t *TransportBasicIO) SetVersion(version string) {
+	t.version = version
+}
+
+// Send frames and writes msg using whichever base version has been
+// negotiated: the 1.0 `]]>]]>` terminator by default, or 1.1 chunked
+// framing once SetVersion("1.1") has been called (see framing.go).
+func (t *TransportBasicIO) Send(msg []byte) error {
+	if t.Version() == baseVersion1_1 {
+		return t.sendChunked(msg)
+	}
+	return t.send1_0(msg)
+}
+
+// Receive reads a single message framed per the negotiated base version.
+func (t *TransportBasicIO) Receive() ([]byte, error) {
+	if t.Version() == baseVersion1_1 {
+		return t.receiveChunked()
+	}
+	return t.receive1_0()
+}
+
+func (t *TransportBasicIO) send1_0(msg []byte) error {
+	_, err := t.Write(append(msg, []byte("\n"+msgSeparator1_0)...))
+	return err
+}
+
+// receive1_0 reads a single message up to the 1.0 end-of-message marker.
+func (t *TransportBasicIO) receive1_0() ([]byte, error) {
+	reader := t.bufReader()
+	msg, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	var out strings.Builder
+	for {
+		if idx := strings.Index(msg, msgSeparator1_0); idx >= 0 {
+			out.WriteString(msg[:idx])
+			break
+		}
+		out.WriteString(msg)
+
+		msg, err = reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+	}
+	return []byte(out.String()), nil
+}
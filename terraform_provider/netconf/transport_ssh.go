@@ -0,0 +1,96 @@
+// Copyright (c) 2018, Juniper Networks, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netconf
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// TransportSSH carries a NETCONF session over the "netconf" SSH subsystem
+// (RFC 6242), for talking to a device that isn't the local Junos shell.
+type TransportSSH struct {
+	TransportBasicIO
+	sshClient  *ssh.Client
+	sshSession *ssh.Session
+}
+
+// Close closes the NETCONF subsystem session and the underlying SSH
+// connection.
+func (t *TransportSSH) Close() error {
+	if t.sshSession != nil {
+		t.sshSession.Close()
+	}
+	if t.sshClient != nil {
+		return t.sshClient.Close()
+	}
+	return nil
+}
+
+// Dial connects to target (host or host:port, defaulting to :830) and
+// requests the netconf subsystem.
+func (t *TransportSSH) Dial(target string, config *ssh.ClientConfig) error {
+	return t.DialTimeout(target, config, 0)
+}
+
+// DialTimeout is like Dial but fails if the TCP connection is not
+// established within timeout. The SSH handshake is not bounded by timeout.
+func (t *TransportSSH) DialTimeout(target string, config *ssh.ClientConfig, timeout time.Duration) error {
+	if !strings.Contains(target, ":") {
+		target = target + ":830"
+	}
+	config.Timeout = timeout
+
+	client, err := ssh.Dial("tcp", target, config)
+	if err != nil {
+		return err
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		client.Close()
+		return err
+	}
+
+	w, err := session.StdinPipe()
+	if err != nil {
+		client.Close()
+		return err
+	}
+	r, err := session.StdoutPipe()
+	if err != nil {
+		client.Close()
+		return err
+	}
+
+	if err := session.RequestSubsystem("netconf"); err != nil {
+		client.Close()
+		return err
+	}
+
+	t.ReadWriteCloser = NewReadWriteCloser(r, w)
+	t.sshClient = client
+	t.sshSession = session
+	return nil
+}
+
+// DialSSH creates a new NETCONF session over SSH.
+func DialSSH(target string, config *ssh.ClientConfig) (*Session, error) {
+	return DialSSHTimeout(target, config, 0)
+}
+
+// DialSSHTimeout is like DialSSH but fails if the TCP connection is not
+// established within timeout. The SSH handshake and the <hello> exchange
+// are not bounded by timeout.
+func DialSSHTimeout(target string, config *ssh.ClientConfig, timeout time.Duration) (*Session, error) {
+	var t TransportSSH
+	if err := t.DialTimeout(target, config, timeout); err != nil {
+		return nil, err
+	}
+
+	return NewSessionFromTransport(&t)
+}